@@ -0,0 +1,50 @@
+package sqlz
+
+import "strings"
+
+// sqlRenderer is satisfied by anything that can render itself to SQL
+// plus bindings, e.g. *SelectStmt and *CompoundSelect. It lets a CTE
+// body be either a plain SELECT or a UNION/UNION ALL composite (for
+// the anchor/recursive terms of a recursive CTE).
+type sqlRenderer interface {
+	ToSQL(rebind bool) (string, []interface{})
+}
+
+// CTE represents a single named entry in a WITH clause.
+type CTE struct {
+	Name    string
+	Columns []string
+	Body    sqlRenderer
+}
+
+func (cte CTE) toSQL() (asSQL string, bindings []interface{}) {
+	bodySQL, bodyBindings := cte.Body.ToSQL(false)
+
+	asSQL = cte.Name
+	if len(cte.Columns) > 0 {
+		asSQL += "(" + strings.Join(cte.Columns, ", ") + ")"
+	}
+	asSQL += " AS (" + bodySQL + ")"
+
+	return asSQL, bodyBindings
+}
+
+// With prepends a named common table expression to the SELECT
+// statement. Multiple calls chain, each adding another CTE to the
+// WITH clause:
+//
+//	db.Select("*").From("active_users").
+//	    With("active_users", []string{"id", "name"}, activeUsersQuery)
+func (stmt *SelectStmt) With(name string, cols []string, body sqlRenderer) *SelectStmt {
+	stmt.CTEs = append(stmt.CTEs, CTE{Name: name, Columns: cols, Body: body})
+	return stmt
+}
+
+// WithRecursive is like With, but marks the WITH clause as
+// RECURSIVE (required as soon as any one of its CTEs is recursive).
+// body is typically a CompoundSelect built with Union/UnionAll,
+// combining the anchor and recursive terms.
+func (stmt *SelectStmt) WithRecursive(name string, cols []string, body sqlRenderer) *SelectStmt {
+	stmt.Recursive = true
+	return stmt.With(name, cols, body)
+}
@@ -0,0 +1,48 @@
+package sqlz
+
+import "testing"
+
+func TestCTEBindingOrder(t *testing.T) {
+	activeUsers := &SelectStmt{Columns: []string{"id", "name"}, Table: "users"}
+	activeUsers.Where(Eq("status", "active"))
+
+	stmt := &SelectStmt{Columns: []string{"*"}, Table: "active_users"}
+	stmt.With("active_users", []string{"id", "name"}, activeUsers)
+	stmt.Where(Eq("id", 42))
+
+	asSQL, bindings := stmt.ToSQL(false)
+	want := "WITH active_users(id, name) AS (SELECT id, name FROM users WHERE status = ?) " +
+		"SELECT * FROM active_users WHERE id = ?"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+
+	wantBindings := []interface{}{"active", 42}
+	for i, b := range wantBindings {
+		if bindings[i] != b {
+			t.Errorf("bindings[%d] = %v, want %v", i, bindings[i], b)
+		}
+	}
+}
+
+func TestWithRecursive(t *testing.T) {
+	anchor := &SelectStmt{Columns: []string{"id", "parent_id"}, Table: "categories"}
+	anchor.Where(Eq("parent_id", Indirect("NULL")))
+
+	recursive := &SelectStmt{Columns: []string{"c.id", "c.parent_id"}, Table: "categories c"}
+	recursive.InnerJoin("tree t", Eq("c.parent_id", Indirect("t.id")))
+
+	body := anchor.UnionAll(recursive)
+
+	stmt := &SelectStmt{Columns: []string{"*"}, Table: "tree"}
+	stmt.WithRecursive("tree", []string{"id", "parent_id"}, body)
+
+	asSQL, _ := stmt.ToSQL(false)
+	want := "WITH RECURSIVE tree(id, parent_id) AS " +
+		"((SELECT id, parent_id FROM categories WHERE parent_id = NULL) UNION ALL " +
+		"(SELECT c.id, c.parent_id FROM categories c INNER JOIN tree t ON c.parent_id = t.id)) " +
+		"SELECT * FROM tree"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+}
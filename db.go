@@ -0,0 +1,34 @@
+package sqlz
+
+import "github.com/jmoiron/sqlx"
+
+// DB wraps sqlx.DB and associates it with a Dialect, so that every
+// statement built from it (e.g. via Select) renders SQL for the
+// correct database system.
+type DB struct {
+	*sqlx.DB
+	Dialect Dialect
+}
+
+// Tx wraps sqlx.Tx and associates it with a Dialect, mirroring DB.
+type Tx struct {
+	*sqlx.Tx
+	Dialect Dialect
+}
+
+// New creates a DB from an existing sqlx.DB connection and a Dialect
+// describing the database system it connects to. Passing a nil
+// dialect defaults to Postgres, matching sqlz's historical behavior.
+func New(db *sqlx.DB, dialect Dialect) *DB {
+	return &DB{DB: db, Dialect: defaultDialect(dialect)}
+}
+
+// Begin starts a transaction on the underlying connection, inheriting
+// the DB's dialect.
+func (db *DB) Begin() (*Tx, error) {
+	tx, err := db.DB.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, Dialect: db.Dialect}, nil
+}
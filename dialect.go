@@ -0,0 +1,269 @@
+package sqlz
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// Dialect abstracts over the syntax differences between database
+// systems, so that the same SelectStmt (and friends) can be rendered
+// correctly regardless of which engine it targets. sqlz defaults to
+// Postgres when no dialect is supplied, to preserve the behavior of
+// earlier versions.
+type Dialect interface {
+	// Name returns a short, lowercase identifier for the dialect
+	// (e.g. "postgres", "mysql").
+	Name() string
+
+	// Rebind rewrites the `?` placeholders produced by ToSQL into
+	// whatever placeholder style the dialect expects ($1, @p1, ? ...).
+	Rebind(sql string) string
+
+	// SupportsDistinctOn reports whether the dialect supports
+	// `DISTINCT ON (...)`. When it doesn't, DistinctColumns are
+	// ignored and a plain `SELECT DISTINCT` is emitted instead.
+	SupportsDistinctOn() bool
+
+	// LimitOffset renders the LIMIT/OFFSET (or dialect equivalent)
+	// tail of a SELECT statement. limit of 0 means "no limit" and
+	// offsetFrom of 0 means "no offset", matching SelectStmt's zero
+	// values.
+	LimitOffset(limit, offsetFrom, offsetRows int64) string
+
+	// LockSQL renders a row/table locking clause for the given
+	// LockClause. ok is false when the dialect has no equivalent for
+	// the requested lock strength, in which case the clause is
+	// dropped entirely rather than emitting invalid SQL.
+	LockSQL(lock *LockClause) (sql string, ok bool)
+
+	// JSONObjectFunc returns the name of the function used to build
+	// a JSON object literal (e.g. "jsonb_build_object", "JSON_OBJECT").
+	JSONObjectFunc() string
+
+	// JSONArrayFunc returns the name of the function used to build
+	// a JSON array literal (e.g. "jsonb_build_array", "JSON_ARRAY").
+	JSONArrayFunc() string
+}
+
+// Postgres is the default Dialect, matching sqlz's historical
+// behavior.
+var Postgres Dialect = postgresDialect{}
+
+// MySQL renders SQL compatible with MySQL/MariaDB.
+var MySQL Dialect = mysqlDialect{}
+
+// SQLite renders SQL compatible with SQLite.
+var SQLite Dialect = sqliteDialect{}
+
+// SQLServer renders SQL compatible with Microsoft SQL Server.
+var SQLServer Dialect = sqlServerDialect{}
+
+// defaultDialect is used whenever a statement has no Dialect set,
+// so that code written before Dialect existed keeps working.
+func defaultDialect(d Dialect) Dialect {
+	if d == nil {
+		return Postgres
+	}
+	return d
+}
+
+// rebindSequential replaces each `?` in sql with the result of next,
+// called once per placeholder in order. It's shared by dialects whose
+// placeholder is a function of its position (Postgres, SQL Server). A
+// doubled `??` (used to escape Postgres's jsonb `?`/`?|`/`?&`
+// operators) collapses to a single literal `?` instead of being
+// treated as a placeholder.
+func rebindSequential(sql string, next func(n int) string) string {
+	var buf bytes.Buffer
+	n := 0
+	for i := 0; i < len(sql); i++ {
+		if sql[i] == '?' {
+			if i+1 < len(sql) && sql[i+1] == '?' {
+				buf.WriteByte('?')
+				i++
+				continue
+			}
+			n++
+			buf.WriteString(next(n))
+			continue
+		}
+		buf.WriteByte(sql[i])
+	}
+	return buf.String()
+}
+
+// unescapeQuestionMarks collapses a doubled `??` escape into a single
+// literal `?`, for dialects whose own placeholder is `?` and so have
+// nothing else to rebind.
+func unescapeQuestionMarks(sql string) string {
+	return strings.ReplaceAll(sql, "??", "?")
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return "postgres" }
+
+func (postgresDialect) Rebind(sql string) string {
+	return rebindSequential(sql, func(n int) string { return "$" + strconv.Itoa(n) })
+}
+
+func (postgresDialect) SupportsDistinctOn() bool { return true }
+
+func (postgresDialect) LimitOffset(limit, offsetFrom, offsetRows int64) string {
+	var buf bytes.Buffer
+	if limit > 0 {
+		buf.WriteString(" LIMIT " + strconv.FormatInt(limit, 10))
+	}
+	if offsetFrom > 0 {
+		offset := strconv.FormatInt(offsetFrom, 10)
+		if offsetRows > 0 {
+			offset += " " + strconv.FormatInt(offsetRows, 10)
+		}
+		buf.WriteString(" OFFSET " + offset)
+	}
+	return buf.String()
+}
+
+func (postgresDialect) LockSQL(lock *LockClause) (string, bool) {
+	return defaultLockSQL(lock)
+}
+
+func (postgresDialect) JSONObjectFunc() string { return "jsonb_build_object" }
+func (postgresDialect) JSONArrayFunc() string  { return "jsonb_build_array" }
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string { return "mysql" }
+
+func (mysqlDialect) Rebind(sql string) string { return unescapeQuestionMarks(sql) }
+
+func (mysqlDialect) SupportsDistinctOn() bool { return false }
+
+func (mysqlDialect) LimitOffset(limit, offsetFrom, offsetRows int64) string {
+	var buf bytes.Buffer
+	if limit > 0 {
+		buf.WriteString(" LIMIT " + strconv.FormatInt(limit, 10))
+	}
+	if offsetFrom > 0 {
+		buf.WriteString(" OFFSET " + strconv.FormatInt(offsetFrom, 10))
+	}
+	return buf.String()
+}
+
+func (mysqlDialect) LockSQL(lock *LockClause) (string, bool) {
+	switch lock.Strength {
+	case LockForUpdate:
+		return lockSQLWithWait("FOR UPDATE", lock), true
+	case LockForShare:
+		// MySQL's closest equivalent to FOR SHARE is LOCK IN SHARE MODE
+		// (pre-8.0) / FOR SHARE (8.0+); we emit the modern form.
+		return lockSQLWithWait("FOR SHARE", lock), true
+	default:
+		// FOR NO KEY UPDATE and FOR KEY SHARE are Postgres-only
+		// tuple-level lock modes with no MySQL equivalent.
+		return "", false
+	}
+}
+
+func (mysqlDialect) JSONObjectFunc() string { return "JSON_OBJECT" }
+func (mysqlDialect) JSONArrayFunc() string  { return "JSON_ARRAY" }
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+func (sqliteDialect) Rebind(sql string) string { return unescapeQuestionMarks(sql) }
+
+func (sqliteDialect) SupportsDistinctOn() bool { return false }
+
+func (sqliteDialect) LimitOffset(limit, offsetFrom, offsetRows int64) string {
+	var buf bytes.Buffer
+	if limit > 0 {
+		buf.WriteString(" LIMIT " + strconv.FormatInt(limit, 10))
+	}
+	if offsetFrom > 0 {
+		buf.WriteString(" OFFSET " + strconv.FormatInt(offsetFrom, 10))
+	}
+	return buf.String()
+}
+
+func (sqliteDialect) LockSQL(lock *LockClause) (string, bool) {
+	// SQLite has no row-level locking model (the whole database is
+	// locked at the file level), so every lock clause is dropped.
+	return "", false
+}
+
+func (sqliteDialect) JSONObjectFunc() string { return "json_object" }
+func (sqliteDialect) JSONArrayFunc() string  { return "json_array" }
+
+type sqlServerDialect struct{}
+
+func (sqlServerDialect) Name() string { return "sqlserver" }
+
+func (sqlServerDialect) Rebind(sql string) string {
+	return rebindSequential(sql, func(n int) string { return "@p" + strconv.Itoa(n) })
+}
+
+func (sqlServerDialect) SupportsDistinctOn() bool { return false }
+
+func (sqlServerDialect) LimitOffset(limit, offsetFrom, offsetRows int64) string {
+	// SQL Server has no bare LIMIT/OFFSET; both require ORDER BY and
+	// are expressed as OFFSET ... ROWS FETCH NEXT ... ROWS ONLY.
+	if limit == 0 && offsetFrom == 0 {
+		return ""
+	}
+	var buf bytes.Buffer
+	buf.WriteString(" OFFSET " + strconv.FormatInt(offsetFrom, 10) + " ROWS")
+	if limit > 0 {
+		buf.WriteString(" FETCH NEXT " + strconv.FormatInt(limit, 10) + " ROWS ONLY")
+	}
+	return buf.String()
+}
+
+func (sqlServerDialect) LockSQL(lock *LockClause) (string, bool) {
+	switch lock.Strength {
+	case LockForUpdate:
+		return "WITH (UPDLOCK, ROWLOCK)", true
+	case LockForShare:
+		return "WITH (HOLDLOCK, ROWLOCK)", true
+	default:
+		return "", false
+	}
+}
+
+func (sqlServerDialect) JSONObjectFunc() string { return "JSON_OBJECT" }
+func (sqlServerDialect) JSONArrayFunc() string  { return "JSON_ARRAY" }
+
+// defaultLockSQL renders a lock clause using the Postgres grammar,
+// which MySQL also understands for the strengths it supports.
+func defaultLockSQL(lock *LockClause) (string, bool) {
+	var lockStrength string
+	switch lock.Strength {
+	case LockForUpdate:
+		lockStrength = "FOR UPDATE"
+	case LockForNoKeyUpdate:
+		lockStrength = "FOR NO KEY UPDATE"
+	case LockForShare:
+		lockStrength = "FOR SHARE"
+	case LockForKeyShare:
+		lockStrength = "FOR KEY SHARE"
+	default:
+		return "", false
+	}
+	return lockSQLWithWait(lockStrength, lock), true
+}
+
+func lockSQLWithWait(lockStrength string, lock *LockClause) string {
+	sql := lockStrength
+	if len(lock.Tables) > 0 {
+		sql += " OF " + strings.Join(lock.Tables, ", ")
+	}
+	switch lock.Wait {
+	case LockNoWait:
+		sql += " NOWAIT"
+	case LockSkipLocked:
+		sql += " SKIP LOCKED"
+	}
+	return sql
+}
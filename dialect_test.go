@@ -0,0 +1,102 @@
+package sqlz
+
+import "testing"
+
+func TestToSQLDialects(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		build   func() *SelectStmt
+		wantSQL string
+	}{
+		{
+			name:    "postgres placeholders and DISTINCT ON",
+			dialect: Postgres,
+			build: func() *SelectStmt {
+				stmt := &SelectStmt{Columns: []string{"*"}, Table: "users", Dialect: Postgres}
+				stmt.Distinct("country")
+				stmt.Where(Eq("active", true))
+				stmt.Limit(10)
+				stmt.Offset(5)
+				return stmt
+			},
+			wantSQL: "SELECT DISTINCT ON (country) * FROM users WHERE active = $1 LIMIT 10 OFFSET 5",
+		},
+		{
+			name:    "mysql placeholders and plain DISTINCT",
+			dialect: MySQL,
+			build: func() *SelectStmt {
+				stmt := &SelectStmt{Columns: []string{"*"}, Table: "users", Dialect: MySQL}
+				stmt.Distinct("country")
+				stmt.Where(Eq("active", true))
+				stmt.Limit(10)
+				stmt.Offset(5)
+				return stmt
+			},
+			wantSQL: "SELECT DISTINCT * FROM users WHERE active = ? LIMIT 10 OFFSET 5",
+		},
+		{
+			name:    "sqlite placeholders and plain DISTINCT",
+			dialect: SQLite,
+			build: func() *SelectStmt {
+				stmt := &SelectStmt{Columns: []string{"*"}, Table: "users", Dialect: SQLite}
+				stmt.Distinct("country")
+				stmt.Where(Eq("active", true))
+				stmt.Limit(10)
+				stmt.Offset(5)
+				return stmt
+			},
+			wantSQL: "SELECT DISTINCT * FROM users WHERE active = ? LIMIT 10 OFFSET 5",
+		},
+		{
+			name:    "sqlserver placeholders and OFFSET...FETCH",
+			dialect: SQLServer,
+			build: func() *SelectStmt {
+				stmt := &SelectStmt{Columns: []string{"*"}, Table: "users", Dialect: SQLServer}
+				stmt.Distinct("country")
+				stmt.Where(Eq("active", true))
+				stmt.Limit(10)
+				stmt.Offset(5)
+				return stmt
+			},
+			wantSQL: "SELECT DISTINCT * FROM users WHERE active = @p1 OFFSET 5 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asSQL, _ := tt.build().ToSQL(true)
+			if asSQL != tt.wantSQL {
+				t.Errorf("ToSQL = %q, want %q", asSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestLockSQLPerDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		lock    *LockClause
+		wantSQL string
+	}{
+		{"postgres FOR UPDATE", Postgres, ForUpdate(), "SELECT * FROM users FOR UPDATE"},
+		{"postgres FOR NO KEY UPDATE", Postgres, ForNoKeyUpdate(), "SELECT * FROM users FOR NO KEY UPDATE"},
+		{"mysql FOR UPDATE", MySQL, ForUpdate(), "SELECT * FROM users FOR UPDATE"},
+		{"mysql FOR NO KEY UPDATE dropped", MySQL, ForNoKeyUpdate(), "SELECT * FROM users"},
+		{"sqlite FOR UPDATE dropped", SQLite, ForUpdate(), "SELECT * FROM users"},
+		{"sqlserver FOR UPDATE", SQLServer, ForUpdate(), "SELECT * FROM users WITH (UPDLOCK, ROWLOCK)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := &SelectStmt{Columns: []string{"*"}, Table: "users", Dialect: tt.dialect}
+			stmt.Lock(tt.lock)
+
+			asSQL, _ := stmt.ToSQL(true)
+			if asSQL != tt.wantSQL {
+				t.Errorf("ToSQL = %q, want %q", asSQL, tt.wantSQL)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package sqlz
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ctxExecQueryer is the subset of *sqlx.DB/*sqlx.Tx needed to stream
+// results: running the SELECT itself (QueryxContext) and, in cursor
+// mode, issuing DECLARE/FETCH/CLOSE (ExecContext).
+type ctxExecQueryer interface {
+	sqlx.QueryerContext
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// txBeginner starts a transaction; it's only set on statements built
+// from a DB (statements built from a Tx already have one to reuse).
+type txBeginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
+var cursorCounter uint64
+
+func nextCursorName() string {
+	return fmt.Sprintf("sqlz_cursor_%d", atomic.AddUint64(&cursorCounter, 1))
+}
+
+// CursorSize marks the statement to be executed through a
+// server-side cursor, fetching rows rows at a time (via
+// "DECLARE ... CURSOR" / "FETCH FORWARD") instead of asking the
+// driver to stream the whole result set at once. Only meaningful
+// together with Iterate.
+func (stmt *SelectStmt) CursorSize(rows int64) *SelectStmt {
+	stmt.CursorRows = rows
+	return stmt
+}
+
+// Rows executes the SELECT statement and returns the resulting
+// *sqlx.Rows for manual iteration, without materializing every row
+// like GetAll does. The caller is responsible for closing it.
+func (stmt *SelectStmt) Rows(ctx context.Context) (*sqlx.Rows, error) {
+	asSQL, bindings := stmt.ToSQL(true)
+	return stmt.ctxQueryer.QueryxContext(ctx, asSQL, bindings...)
+}
+
+// Iterate executes the SELECT statement and calls fn once per
+// result row, passing a scan function with GetAll-equivalent
+// struct-scan semantics: call scan(&someStruct) to StructScan the
+// row, or scan(&col1, &col2, ...) to scan individual columns.
+// Returning an error from fn stops iteration early and is returned
+// by Iterate. If CursorSize was set, rows are fetched from a
+// server-side cursor in batches instead of all at once; the cursor
+// is always closed, whether iteration finishes, fn returns an error,
+// or the query itself fails.
+func (stmt *SelectStmt) Iterate(ctx context.Context, fn func(scan func(dest ...interface{}) error) error) error {
+	if stmt.CursorRows > 0 {
+		return stmt.iterateCursor(ctx, fn)
+	}
+
+	rows, err := stmt.Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if err := iterateRows(rows, fn); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+func iterateRows(rows *sqlx.Rows, fn func(scan func(dest ...interface{}) error) error) error {
+	scan := func(dest ...interface{}) error {
+		if len(dest) == 1 {
+			return rows.StructScan(dest[0])
+		}
+		return rows.Scan(dest...)
+	}
+
+	for rows.Next() {
+		if err := fn(scan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterateCursor streams the statement's results through a
+// server-side cursor, declared inside a transaction (reusing the
+// statement's transaction if it was built from one, or starting a new
+// one otherwise) and fetched CursorRows rows at a time.
+func (stmt *SelectStmt) iterateCursor(ctx context.Context, fn func(scan func(dest ...interface{}) error) error) (err error) {
+	if defaultDialect(stmt.Dialect) != Postgres {
+		return errors.New("sqlz: CursorSize is only supported on Postgres")
+	}
+
+	tx, ok := stmt.ctxQueryer.(*sqlx.Tx)
+	if !ok {
+		if stmt.txBeginner == nil {
+			return errors.New("sqlz: CursorSize requires a database/transaction connection")
+		}
+		tx, err = stmt.txBeginner.BeginTxx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				tx.Rollback()
+				return
+			}
+			err = tx.Commit()
+		}()
+	}
+
+	asSQL, bindings := stmt.ToSQL(true)
+	cursor := nextCursorName()
+
+	if _, err = tx.ExecContext(ctx, "DECLARE "+cursor+" CURSOR FOR "+asSQL, bindings...); err != nil {
+		return err
+	}
+	defer tx.ExecContext(ctx, "CLOSE "+cursor)
+
+	fetchSQL := fmt.Sprintf("FETCH FORWARD %d FROM %s", stmt.CursorRows, cursor)
+
+	for {
+		var rows *sqlx.Rows
+		rows, err = tx.QueryxContext(ctx, fetchSQL)
+		if err != nil {
+			return err
+		}
+
+		scan := func(dest ...interface{}) error {
+			if len(dest) == 1 {
+				return rows.StructScan(dest[0])
+			}
+			return rows.Scan(dest...)
+		}
+
+		var fetched int64
+		for rows.Next() {
+			fetched++
+			if err = fn(scan); err != nil {
+				rows.Close()
+				return err
+			}
+		}
+		if err = rows.Err(); err != nil {
+			rows.Close()
+			return err
+		}
+		rows.Close()
+
+		if fetched < stmt.CursorRows {
+			return nil
+		}
+	}
+}
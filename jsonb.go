@@ -36,23 +36,35 @@ func BuildJSONBArray(in ...interface{}) (out JSONBBuilder) {
 	return out
 }
 
+// Parse renders the builder's SQL using Postgres's jsonb_build_object/
+// jsonb_build_array functions. To target another database system, use
+// ParseDialect instead.
 func (b JSONBBuilder) Parse() (asSQL string, bindings []interface{}) {
-	asSQL = "jsonb_build_"
+	return b.ParseDialect(Postgres)
+}
+
+// ParseDialect renders the builder's SQL using the JSON object/array
+// constructor functions named by dialect (e.g. jsonb_build_object on
+// Postgres, JSON_OBJECT on MySQL), recursing into nested objects and
+// arrays with the same dialect.
+func (b JSONBBuilder) ParseDialect(dialect Dialect) (asSQL string, bindings []interface{}) {
+	dialect = defaultDialect(dialect)
+
 	if b.Array {
-		asSQL += "array("
+		asSQL = dialect.JSONArrayFunc() + "("
 	} else {
-		asSQL += "object("
+		asSQL = dialect.JSONObjectFunc() + "("
 	}
 
 	var placeholders []string
 
 	for _, val := range b.Bindings {
 		if object, isObject := val.(map[string]interface{}); isObject {
-			subSQL, subBindings := BuildJSONBObject(object).Parse()
+			subSQL, subBindings := BuildJSONBObject(object).ParseDialect(dialect)
 			placeholders = append(placeholders, subSQL)
 			bindings = append(bindings, subBindings...)
 		} else if array, isArray := val.([]interface{}); isArray {
-			subSQL, subBindings := BuildJSONBArray(array...).Parse()
+			subSQL, subBindings := BuildJSONBArray(array...).ParseDialect(dialect)
 			placeholders = append(placeholders, subSQL)
 			bindings = append(bindings, subBindings...)
 		} else {
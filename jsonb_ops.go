@@ -0,0 +1,118 @@
+package sqlz
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// jsonbCondition is a WhereCondition for a Postgres JSON(B) operator
+// that compares a column against a JSON-encoded value, e.g.
+// "col @> ?::jsonb".
+type jsonbCondition struct {
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+func (c jsonbCondition) parse() (string, []interface{}) {
+	data, err := json.Marshal(c.Value)
+	if err != nil {
+		// Value wasn't JSON-encodable; bind it as-is and let the
+		// driver/database surface the error.
+		return c.Column + " " + c.Operator + " ?::jsonb", []interface{}{c.Value}
+	}
+	return c.Column + " " + c.Operator + " ?::jsonb", []interface{}{string(data)}
+}
+
+// JSONBContains creates a "col @> value" condition, true when the
+// jsonb column contains the provided value (usually a map or slice).
+func JSONBContains(col string, value interface{}) WhereCondition {
+	return jsonbCondition{Column: col, Operator: "@>", Value: value}
+}
+
+// JSONBContainedBy creates a "col <@ value" condition, true when the
+// jsonb column is contained within the provided value.
+func JSONBContainedBy(col string, value interface{}) WhereCondition {
+	return jsonbCondition{Column: col, Operator: "<@", Value: value}
+}
+
+// jsonbKeyCondition is a WhereCondition for the Postgres jsonb key
+// existence operators (?, ?|, ?&). The operator's leading "?" is
+// doubled (e.g. "??", "??|") so that Dialect.Rebind doesn't mistake
+// it for a placeholder.
+type jsonbKeyCondition struct {
+	Column   string
+	Operator string
+	Keys     []string
+}
+
+func (c jsonbKeyCondition) parse() (string, []interface{}) {
+	// escape the operator's leading "?" (e.g. "?" -> "??", "?|" -> "??|")
+	// so Dialect.Rebind doesn't mistake it for a placeholder.
+	escapedOp := "?" + c.Operator
+
+	if c.Operator == "?" {
+		return c.Column + " " + escapedOp + " ?", []interface{}{c.Keys[0]}
+	}
+	// ?| and ?& take a text array; bind it as a Postgres array
+	// literal so no array-binding helper (e.g. pq.Array) is required.
+	return c.Column + " " + escapedOp + " ?", []interface{}{"{" + strings.Join(c.Keys, ",") + "}"}
+}
+
+// JSONBHasKey creates a "col ? key" condition, true when the jsonb
+// column has a top-level key matching key.
+func JSONBHasKey(col, key string) WhereCondition {
+	return jsonbKeyCondition{Column: col, Operator: "?", Keys: []string{key}}
+}
+
+// JSONBHasAnyKeys creates a "col ?| keys" condition, true when the
+// jsonb column has any of the top-level keys.
+func JSONBHasAnyKeys(col string, keys ...string) WhereCondition {
+	return jsonbKeyCondition{Column: col, Operator: "?|", Keys: keys}
+}
+
+// JSONBHasAllKeys creates a "col ?& keys" condition, true when the
+// jsonb column has all of the top-level keys.
+func JSONBHasAllKeys(col string, keys ...string) WhereCondition {
+	return jsonbKeyCondition{Column: col, Operator: "?&", Keys: keys}
+}
+
+// JSONBPath extracts a value at the given path from a jsonb column
+// using the "#>" operator, e.g. JSONBPath("data", "a", "b") renders
+// as `data #> '{a,b}'`. The result is an Indirect, so it can be used
+// verbatim as a SELECT column (cast with string(...)) or as one side
+// of a WhereCondition (e.g. Eq("parent_id", sqlz.JSONBPath(...))).
+func JSONBPath(col string, path ...string) Indirect {
+	return Indirect(col + ` #> '{` + strings.Join(path, ",") + `}'`)
+}
+
+// JSONBMerge represents two JSONB object/array builders concatenated
+// with the "||" operator, producing an upsert-style merge payload.
+type JSONBMerge struct {
+	Left, Right JSONBBuilder
+}
+
+// BuildJSONBMerge creates a JSONBMerge combining a and b with "||",
+// e.g. `jsonb_build_object(...) || jsonb_build_object(...)`.
+func BuildJSONBMerge(a, b JSONBBuilder) JSONBMerge {
+	return JSONBMerge{Left: a, Right: b}
+}
+
+// Parse renders the merge's SQL using Postgres's jsonb_build_object/
+// jsonb_build_array functions. To target another database system, use
+// ParseDialect instead.
+func (m JSONBMerge) Parse() (asSQL string, bindings []interface{}) {
+	return m.ParseDialect(Postgres)
+}
+
+// ParseDialect renders the merge's SQL using dialect's JSON
+// constructor functions for both sides.
+func (m JSONBMerge) ParseDialect(dialect Dialect) (asSQL string, bindings []interface{}) {
+	leftSQL, leftBindings := m.Left.ParseDialect(dialect)
+	rightSQL, rightBindings := m.Right.ParseDialect(dialect)
+
+	bindings = append(bindings, leftBindings...)
+	bindings = append(bindings, rightBindings...)
+
+	return leftSQL + " || " + rightSQL, bindings
+}
@@ -0,0 +1,68 @@
+package sqlz
+
+import "testing"
+
+func TestJSONBKeyConditions(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition WhereCondition
+		wantSQL   string
+		wantArg   interface{}
+	}{
+		{
+			name:      "has key",
+			condition: JSONBHasKey("data", "a"),
+			wantSQL:   "data ?? ?",
+			wantArg:   "a",
+		},
+		{
+			name:      "has any keys",
+			condition: JSONBHasAnyKeys("data", "a", "b"),
+			wantSQL:   "data ??| ?",
+			wantArg:   "{a,b}",
+		},
+		{
+			name:      "has all keys",
+			condition: JSONBHasAllKeys("data", "a", "b"),
+			wantSQL:   "data ??& ?",
+			wantArg:   "{a,b}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asSQL, bindings := parseConditions([]WhereCondition{tt.condition}, " AND ")
+			if asSQL != tt.wantSQL {
+				t.Errorf("asSQL = %q, want %q", asSQL, tt.wantSQL)
+			}
+			if len(bindings) != 1 || bindings[0] != tt.wantArg {
+				t.Errorf("bindings = %v, want [%v]", bindings, tt.wantArg)
+			}
+		})
+	}
+}
+
+func TestJSONBKeyConditionsRebind(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition WhereCondition
+		wantSQL   string
+	}{
+		{"has key", JSONBHasKey("data", "a"), "data ? $1"},
+		{"has any keys", JSONBHasAnyKeys("data", "a", "b"), "data ?| $1"},
+		{"has all keys", JSONBHasAllKeys("data", "a", "b"), "data ?& $1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := &SelectStmt{Columns: []string{"*"}, Table: "docs"}
+			stmt.Where(tt.condition)
+
+			asSQL, _ := stmt.ToSQL(true)
+			want := "SELECT * FROM docs WHERE " + tt.wantSQL
+			if asSQL != want {
+				t.Errorf("ToSQL = %q, want %q", asSQL, want)
+			}
+		})
+	}
+}
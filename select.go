@@ -2,7 +2,6 @@ package sqlz
 
 import (
 	"bytes"
-	"fmt"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -31,10 +30,13 @@ const (
 
 // SelectStmt represents a SELECT statement
 type SelectStmt struct {
+	CTEs            []CTE
+	Recursive       bool
 	IsDistinct      bool
 	DistinctColumns []string
 	Columns         []string
 	Table           string
+	FromResultSet   sqlRenderer
 	Joins           []JoinClause
 	Conditions      []WhereCondition
 	Ordering        []OrderColumn
@@ -44,7 +46,11 @@ type SelectStmt struct {
 	LimitTo         int64
 	OffsetFrom      int64
 	OffsetRows      int64
+	Dialect         Dialect
+	CursorRows      int64
 	queryer         sqlx.Queryer
+	ctxQueryer      ctxExecQueryer
+	txBeginner      txBeginner
 }
 
 // JoinClause represents a JOIN clause in a
@@ -52,7 +58,8 @@ type SelectStmt struct {
 type JoinClause struct {
 	Type       JoinType
 	Table      string
-	ResultSet  *SelectStmt
+	ResultSet  sqlRenderer
+	Lateral    bool
 	Conditions []WhereCondition
 }
 
@@ -98,34 +105,85 @@ const (
 	LockSkipLocked
 )
 
-// OrderColumn represents a column in an ORDER BY
-// clause (with direction)
+// NullsOrder controls where NULL values sort relative to non-NULL
+// ones in an ORDER BY clause.
+type NullsOrder int8
+
+const (
+	// NullsDefault leaves NULL placement up to the database system.
+	NullsDefault NullsOrder = iota
+	// NullsFirstOrder sorts NULL values before non-NULL ones.
+	NullsFirstOrder
+	// NullsLastOrder sorts NULL values after non-NULL ones.
+	NullsLastOrder
+)
+
+// OrderColumn represents a single expression in an ORDER BY clause.
+// Use Asc/Desc for plain column names, or OrderByExpr for raw SQL
+// expressions (optionally with bound arguments).
 type OrderColumn struct {
 	Column string
 	Desc   bool
+	Raw    bool
+	Args   []interface{}
+	Nulls  NullsOrder
 }
 
 // ToSQL generates SQL for an OrderColumn
 func (o OrderColumn) ToSQL() string {
 	str := o.Column
-	if o.Desc {
-		str += " DESC"
-	} else {
-		str += " ASC"
+	if !o.Raw {
+		if o.Desc {
+			str += " DESC"
+		} else {
+			str += " ASC"
+		}
+	}
+	switch o.Nulls {
+	case NullsFirstOrder:
+		str += " NULLS FIRST"
+	case NullsLastOrder:
+		str += " NULLS LAST"
 	}
 	return str
 }
 
+// NullsFirst returns a copy of the OrderColumn with a NULLS FIRST
+// modifier appended.
+func (o OrderColumn) NullsFirst() OrderColumn {
+	o.Nulls = NullsFirstOrder
+	return o
+}
+
+// NullsLast returns a copy of the OrderColumn with a NULLS LAST
+// modifier appended.
+func (o OrderColumn) NullsLast() OrderColumn {
+	o.Nulls = NullsLastOrder
+	return o
+}
+
 // Asc creates an OrderColumn for the provided
 // column in ascending order
 func Asc(col string) OrderColumn {
-	return OrderColumn{col, false}
+	return OrderColumn{Column: col, Desc: false}
 }
 
 // Desc creates an OrderColumn for the provided
 // column in descending order
 func Desc(col string) OrderColumn {
-	return OrderColumn{col, true}
+	return OrderColumn{Column: col, Desc: true}
+}
+
+// OrderByExpr creates an OrderColumn from a raw SQL expression with
+// optional bound arguments, e.g.:
+//
+//	sqlz.OrderByExpr("CASE WHEN status = ? THEN 0 ELSE 1 END", "active")
+//	sqlz.OrderByExpr("ts_rank(search, to_tsquery(?)) DESC", q)
+//
+// Unlike Asc/Desc, the expression is emitted verbatim - include
+// ASC/DESC in expr yourself if you need one.
+func OrderByExpr(expr string, args ...interface{}) OrderColumn {
+	return OrderColumn{Column: expr, Raw: true, Args: append([]interface{}{}, args...)}
 }
 
 // Select creates a new SelectStmt object, selecting
@@ -134,8 +192,11 @@ func Desc(col string) OrderColumn {
 // Select("one", "two t", "MAX(three) maxThree")
 func (db *DB) Select(cols ...string) *SelectStmt {
 	return &SelectStmt{
-		Columns: append([]string{}, cols...),
-		queryer: db.DB,
+		Columns:    append([]string{}, cols...),
+		Dialect:    db.Dialect,
+		queryer:    db.DB,
+		ctxQueryer: db.DB,
+		txBeginner: db.DB,
 	}
 }
 
@@ -145,8 +206,10 @@ func (db *DB) Select(cols ...string) *SelectStmt {
 // Select("one", "two t", "MAX(three) maxThree")
 func (tx *Tx) Select(cols ...string) *SelectStmt {
 	return &SelectStmt{
-		Columns: append([]string{}, cols...),
-		queryer: tx.Tx,
+		Columns:    append([]string{}, cols...),
+		Dialect:    tx.Dialect,
+		queryer:    tx.Tx,
+		ctxQueryer: tx.Tx,
 	}
 }
 
@@ -164,12 +227,30 @@ func (stmt *SelectStmt) From(table string) *SelectStmt {
 	return stmt
 }
 
+// FromValues sets a ValuesClause as the statement's FROM source,
+// rendering as:
+//
+//	FROM (VALUES (?, ?), (?, ?)) alias(col1, col2)
+//
+// v's Dialect is set to the statement's own Dialect, unless already set.
+func (stmt *SelectStmt) FromValues(v *ValuesClause, alias string) *SelectStmt {
+	if v.Dialect == nil {
+		v.Dialect = stmt.Dialect
+	}
+	stmt.FromResultSet = v
+	stmt.Table = alias
+	return stmt
+}
+
 // Join creates a new join with the supplied type, on the
 // supplied table or result set (a sub-select statement),
 // using the provided conditions. Since conditions in a
 // JOIN clause usually compare two columns, use sqlz.Indirect
 // in your conditions.
-func (stmt *SelectStmt) Join(joinType JoinType, table string, resultSet *SelectStmt, conds ...WhereCondition) *SelectStmt {
+func (stmt *SelectStmt) Join(joinType JoinType, table string, resultSet sqlRenderer, conds ...WhereCondition) *SelectStmt {
+	if values, ok := resultSet.(*ValuesClause); ok && values.Dialect == nil {
+		values.Dialect = stmt.Dialect
+	}
 	stmt.Joins = append(stmt.Joins, JoinClause{
 		Type:       joinType,
 		Table:      table,
@@ -179,6 +260,36 @@ func (stmt *SelectStmt) Join(joinType JoinType, table string, resultSet *SelectS
 	return stmt
 }
 
+// JoinLateral is like Join, but marks the join as LATERAL, allowing
+// resultSet to reference columns from earlier FROM/JOIN items - handy
+// with set-returning functions like jsonb_array_elements or unnest.
+// If no conditions are given, "ON true" is emitted.
+func (stmt *SelectStmt) JoinLateral(joinType JoinType, table string, resultSet sqlRenderer, conds ...WhereCondition) *SelectStmt {
+	if values, ok := resultSet.(*ValuesClause); ok && values.Dialect == nil {
+		values.Dialect = stmt.Dialect
+	}
+	stmt.Joins = append(stmt.Joins, JoinClause{
+		Type:       joinType,
+		Table:      table,
+		ResultSet:  resultSet,
+		Lateral:    true,
+		Conditions: append([]WhereCondition{}, conds...),
+	})
+	return stmt
+}
+
+// LeftJoinLateral is a wrapper of JoinLateral for creating a
+// LEFT JOIN LATERAL on the results of a sub-query
+func (stmt *SelectStmt) LeftJoinLateral(rs sqlRenderer, as string, conds ...WhereCondition) *SelectStmt {
+	return stmt.JoinLateral(LeftJoin, as, rs, conds...)
+}
+
+// InnerJoinLateral is a wrapper of JoinLateral for creating an
+// INNER JOIN LATERAL on the results of a sub-query
+func (stmt *SelectStmt) InnerJoinLateral(rs sqlRenderer, as string, conds ...WhereCondition) *SelectStmt {
+	return stmt.JoinLateral(InnerJoin, as, rs, conds...)
+}
+
 // LeftJoin is a wrapper of Join for creating a LEFT JOIN on a table
 // with the provided conditions
 func (stmt *SelectStmt) LeftJoin(table string, conds ...WhereCondition) *SelectStmt {
@@ -205,25 +316,25 @@ func (stmt *SelectStmt) FullJoin(table string, conds ...WhereCondition) *SelectS
 
 // LeftJoinRS is a wrapper of Join for creating a LEFT JOIN on the
 // results of a sub-query
-func (stmt *SelectStmt) LeftJoinRS(rs *SelectStmt, as string, conds ...WhereCondition) *SelectStmt {
+func (stmt *SelectStmt) LeftJoinRS(rs sqlRenderer, as string, conds ...WhereCondition) *SelectStmt {
 	return stmt.Join(LeftJoin, as, rs, conds...)
 }
 
 // RightJoinRS is a wrapper of Join for creating a RIGHT JOIN on the
 // results of a sub-query
-func (stmt *SelectStmt) RightJoinRS(rs *SelectStmt, as string, conds ...WhereCondition) *SelectStmt {
+func (stmt *SelectStmt) RightJoinRS(rs sqlRenderer, as string, conds ...WhereCondition) *SelectStmt {
 	return stmt.Join(RightJoin, as, rs, conds...)
 }
 
 // InnerJoinRS is a wrapper of Join for creating a INNER JOIN on the
 // results of a sub-query
-func (stmt *SelectStmt) InnerJoinRS(rs *SelectStmt, as string, conds ...WhereCondition) *SelectStmt {
+func (stmt *SelectStmt) InnerJoinRS(rs sqlRenderer, as string, conds ...WhereCondition) *SelectStmt {
 	return stmt.Join(InnerJoin, as, rs, conds...)
 }
 
 // FullJoinRS is a wrapper of Join for creating a FULL JOIN on the
 // results of a sub-query
-func (stmt *SelectStmt) FullJoinRS(rs *SelectStmt, as string, conds ...WhereCondition) *SelectStmt {
+func (stmt *SelectStmt) FullJoinRS(rs sqlRenderer, as string, conds ...WhereCondition) *SelectStmt {
 	return stmt.Join(FullJoin, as, rs, conds...)
 }
 
@@ -303,42 +414,75 @@ func ForKeyShare() *LockClause {
 // exported if you wish to use it directly.
 func (stmt *SelectStmt) ToSQL(rebind bool) (asSQL string, bindings []interface{}) {
 
+	dialect := defaultDialect(stmt.Dialect)
+
 	var buf bytes.Buffer
 
-	buf.WriteString("SELECT")
+	if len(stmt.CTEs) > 0 {
+		buf.WriteString("WITH ")
+		if stmt.Recursive {
+			buf.WriteString("RECURSIVE ")
+		}
 
-	// var clauses = []string{"SELECT"}
+		var ctes []string
+		for _, cte := range stmt.CTEs {
+			cteSQL, cteBindings := cte.toSQL()
+			ctes = append(ctes, cteSQL)
+			bindings = append(bindings, cteBindings...)
+		}
+		buf.WriteString(strings.Join(ctes, ", "))
+		buf.WriteString(" ")
+	}
+
+	buf.WriteString("SELECT")
 
 	if stmt.IsDistinct {
 		buf.WriteString(" DISTINCT")
-		// clauses = append(clauses, "DISTINCT")
-		if len(stmt.DistinctColumns) > 0 {
+		if len(stmt.DistinctColumns) > 0 && dialect.SupportsDistinctOn() {
 			buf.WriteString(" ON (" + strings.Join(stmt.DistinctColumns, ", ") + ")")
-			// clauses = append(clauses, "ON ("+strings.Join(stmt.DistinctColumns, ", ")+")")
 		}
 	}
 
 	if len(stmt.Columns) == 0 {
 		buf.WriteString(" *")
-		// clauses = append(clauses, "*")
 	} else {
 		buf.WriteString(" " + strings.Join(stmt.Columns, ", "))
-		// clauses = append(clauses, strings.Join(stmt.Columns, ", "))
 	}
-	buf.WriteString(" FROM " + stmt.Table)
-	// clauses = append(clauses, "FROM "+stmt.Table)
+	if stmt.FromResultSet != nil {
+		fromSQL, fromBindings := stmt.FromResultSet.ToSQL(false)
+		alias := stmt.Table
+		if values, ok := stmt.FromResultSet.(*ValuesClause); ok && len(values.Columns) > 0 {
+			alias += "(" + strings.Join(values.Columns, ", ") + ")"
+		}
+		buf.WriteString(" FROM (" + fromSQL + ") " + alias)
+		bindings = append(bindings, fromBindings...)
+	} else {
+		buf.WriteString(" FROM " + stmt.Table)
+	}
 
 	for _, join := range stmt.Joins {
-		onClause, joinBindings := parseConditions(join.Conditions)
+		onClause, joinBindings := parseConditions(join.Conditions, " AND ")
+		if onClause == "" {
+			// a LATERAL join correlated with an earlier FROM item often
+			// has nothing left to filter on
+			onClause = "true"
+		}
+
+		lateral := ""
+		if join.Lateral {
+			lateral = "LATERAL "
+		}
 
 		if join.ResultSet != nil {
 			rsSQL, rsBindings := join.ResultSet.ToSQL(false)
-			// clauses = append(clauses, join.Type.String()+" ("+rsSQL+") "+join.Table+" ON "+onClause)
-			buf.WriteString(" " + join.Type.String() + " (" + rsSQL + ") " + join.Table + " ON " + onClause)
+			alias := join.Table
+			if values, ok := join.ResultSet.(*ValuesClause); ok && len(values.Columns) > 0 {
+				alias += "(" + strings.Join(values.Columns, ", ") + ")"
+			}
+			buf.WriteString(" " + join.Type.String() + " " + lateral + "(" + rsSQL + ") " + alias + " ON " + onClause)
 			bindings = append(bindings, rsBindings...)
 		} else {
-			// clauses = append(clauses, join.Type.String()+" "+join.Table+" ON "+onClause)
-			buf.WriteString(" " + join.Type.String() + " " + join.Table + " ON " + onClause)
+			buf.WriteString(" " + join.Type.String() + " " + lateral + join.Table + " ON " + onClause)
 		}
 
 		// add the join condition bindings (this MUST happen after adding the clause
@@ -348,21 +492,18 @@ func (stmt *SelectStmt) ToSQL(rebind bool) (asSQL string, bindings []interface{}
 	}
 
 	if len(stmt.Conditions) > 0 {
-		whereClause, whereBindings := parseConditions(stmt.Conditions)
+		whereClause, whereBindings := parseConditions(stmt.Conditions, " AND ")
 		bindings = append(bindings, whereBindings...)
-		// clauses = append(clauses, "WHERE "+whereClause)
 		buf.WriteString(" WHERE " + whereClause)
 	}
 
 	if len(stmt.Grouping) > 0 {
-		// clauses = append(clauses, "GROUP BY "+strings.Join(stmt.Grouping, ", "))
 		buf.WriteString(" GROUP BY " + strings.Join(stmt.Grouping, ", "))
 	}
 
 	if len(stmt.GroupConditions) > 0 {
-		groupByClause, groupBindings := parseConditions(stmt.GroupConditions)
+		groupByClause, groupBindings := parseConditions(stmt.GroupConditions, " AND ")
 		bindings = append(bindings, groupBindings...)
-		// clauses = append(clauses, "HAVING "+groupByClause)
 		buf.WriteString(" HAVING " + groupByClause)
 	}
 
@@ -370,68 +511,28 @@ func (stmt *SelectStmt) ToSQL(rebind bool) (asSQL string, bindings []interface{}
 		var ordering []string
 		for _, order := range stmt.Ordering {
 			ordering = append(ordering, order.ToSQL())
+			bindings = append(bindings, order.Args...)
 		}
-		// clauses = append(clauses, "ORDER BY "+strings.Join(ordering, ", "))
 		buf.WriteString(" ORDER BY " + strings.Join(ordering, ", "))
 	}
 
-	if stmt.LimitTo > 0 {
-		// clauses = append(clauses, fmt.Sprintf("LIMIT %d", stmt.LimitTo))
-		buf.WriteString(fmt.Sprintf(" LIMIT %d", stmt.LimitTo))
-	}
-
-	if stmt.OffsetFrom > 0 {
-		offset := fmt.Sprintf("%d", stmt.OffsetFrom)
-		if stmt.OffsetRows > 0 {
-			offset += fmt.Sprintf(" %d", stmt.OffsetRows)
-		}
-		// clauses = append(clauses, "OFFSET "+offset)
-		buf.WriteString(" OFFSET " + offset)
-	}
+	buf.WriteString(dialect.LimitOffset(stmt.LimitTo, stmt.OffsetFrom, stmt.OffsetRows))
 
 	for _, lock := range stmt.Locks {
-		// var lockClause []string
-
-		var lockStrength string
-		switch lock.Strength {
-		case LockForUpdate:
-			lockStrength = "FOR UPDATE"
-		case LockForNoKeyUpdate:
-			lockStrength = "FOR NO KEY UPDATE"
-		case LockForShare:
-			lockStrength = "FOR SHARE"
-		case LockForKeyShare:
-			lockStrength = "FOR KEY SHARE"
-		default:
+		lockSQL, ok := dialect.LockSQL(lock)
+		if !ok {
+			// the dialect has no equivalent for this lock strength
+			// (e.g. FOR NO KEY UPDATE on MySQL); drop it rather than
+			// emit invalid SQL.
 			continue
 		}
-		buf.WriteString(" " + lockStrength)
-		// lockClause = append(lockClause, lockStrength)
-
-		if len(lock.Tables) > 0 {
-			// lockClause = append(lockClause, "OF "+strings.Join(lock.Tables, ", "))
-			buf.WriteString(" OF " + strings.Join(lock.Tables, ", "))
-		}
-
-		switch lock.Wait {
-		case LockNoWait:
-			// lockClause = append(lockClause, "NOWAIT")
-			buf.WriteString(" NOWAIT")
-		case LockSkipLocked:
-			// lockClause = append(lockClause, "SKIP LOCKED")
-			buf.WriteString(" SKIP LOCKED")
-		}
+		buf.WriteString(" " + lockSQL)
 	}
 
-	// asSQL = strings.Join(clauses, " ")
 	asSQL = buf.String()
 
 	if rebind {
-		if db, ok := stmt.queryer.(*sqlx.DB); ok {
-			asSQL = db.Rebind(asSQL)
-		} else if tx, ok := stmt.queryer.(*sqlx.Tx); ok {
-			asSQL = tx.Rebind(asSQL)
-		}
+		asSQL = dialect.Rebind(asSQL)
 	}
 
 	return asSQL, bindings
@@ -0,0 +1,40 @@
+package sqlz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderByExprBindingOrder(t *testing.T) {
+	stmt := &SelectStmt{Columns: []string{"status", "COUNT(*) c"}, Table: "orders"}
+	stmt.GroupBy("status")
+	stmt.Having(Gt("COUNT(*)", 1))
+	stmt.OrderBy(
+		OrderByExpr("CASE WHEN status = ? THEN 0 ELSE 1 END", "shipped"),
+		Desc("c"),
+	)
+	stmt.Limit(5)
+
+	asSQL, bindings := stmt.ToSQL(false)
+	want := "SELECT status, COUNT(*) c FROM orders GROUP BY status HAVING COUNT(*) > ? " +
+		"ORDER BY CASE WHEN status = ? THEN 0 ELSE 1 END, c DESC LIMIT 5"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+
+	wantBindings := []interface{}{1, "shipped"}
+	if !reflect.DeepEqual(bindings, wantBindings) {
+		t.Errorf("bindings = %v, want %v", bindings, wantBindings)
+	}
+}
+
+func TestOrderByNulls(t *testing.T) {
+	stmt := &SelectStmt{Columns: []string{"*"}, Table: "orders"}
+	stmt.OrderBy(Asc("shipped_at").NullsLast(), Desc("id").NullsFirst())
+
+	asSQL, _ := stmt.ToSQL(false)
+	want := "SELECT * FROM orders ORDER BY shipped_at ASC NULLS LAST, id DESC NULLS FIRST"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+}
@@ -0,0 +1,205 @@
+package sqlz
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SetOperator is an enumerated type representing a SQL set operator
+// (UNION, UNION ALL, INTERSECT or EXCEPT) joining two SELECT
+// statements together.
+type SetOperator int
+
+// UnionOperator combines results, removing duplicates.
+// UnionAllOperator combines results, keeping duplicates.
+// IntersectOperator keeps only rows present in both results.
+// ExceptOperator keeps only rows present in the first but not the second.
+const (
+	UnionOperator SetOperator = iota
+	UnionAllOperator
+	IntersectOperator
+	ExceptOperator
+)
+
+// String returns the SQL keyword for the set operator
+func (op SetOperator) String() string {
+	return []string{"UNION", "UNION ALL", "INTERSECT", "EXCEPT"}[int(op)]
+}
+
+// setOperation pairs a SetOperator with the statement on its
+// right-hand side.
+type setOperation struct {
+	Operator SetOperator
+	Stmt     *SelectStmt
+}
+
+// CompoundSelect represents two or more SELECT statements combined
+// with UNION, UNION ALL, INTERSECT or EXCEPT. Per the SQL standard,
+// only the compound statement as a whole may carry an ORDER BY,
+// LIMIT or OFFSET - the branch selects may not (use Limit/OrderBy on
+// a branch only if it's itself wrapped as a sub-select).
+type CompoundSelect struct {
+	Base       *SelectStmt
+	Operations []setOperation
+	Ordering   []OrderColumn
+	LimitTo    int64
+	OffsetFrom int64
+	OffsetRows int64
+	Dialect    Dialect
+	queryer    sqlx.Queryer
+}
+
+func (stmt *SelectStmt) compound(op SetOperator, other *SelectStmt) *CompoundSelect {
+	return &CompoundSelect{
+		Base:       stmt,
+		Operations: []setOperation{{Operator: op, Stmt: other}},
+		Dialect:    stmt.Dialect,
+		queryer:    stmt.queryer,
+	}
+}
+
+// Union combines this SELECT with another, removing duplicate rows
+// (UNION).
+func (stmt *SelectStmt) Union(other *SelectStmt) *CompoundSelect {
+	return stmt.compound(UnionOperator, other)
+}
+
+// UnionAll combines this SELECT with another, keeping duplicate rows
+// (UNION ALL).
+func (stmt *SelectStmt) UnionAll(other *SelectStmt) *CompoundSelect {
+	return stmt.compound(UnionAllOperator, other)
+}
+
+// Intersect keeps only rows returned by both this SELECT and another
+// (INTERSECT).
+func (stmt *SelectStmt) Intersect(other *SelectStmt) *CompoundSelect {
+	return stmt.compound(IntersectOperator, other)
+}
+
+// Except keeps only rows returned by this SELECT but not another
+// (EXCEPT).
+func (stmt *SelectStmt) Except(other *SelectStmt) *CompoundSelect {
+	return stmt.compound(ExceptOperator, other)
+}
+
+// Union appends another SELECT to the compound statement with UNION.
+func (stmt *CompoundSelect) Union(other *SelectStmt) *CompoundSelect {
+	stmt.Operations = append(stmt.Operations, setOperation{Operator: UnionOperator, Stmt: other})
+	return stmt
+}
+
+// UnionAll appends another SELECT to the compound statement with
+// UNION ALL.
+func (stmt *CompoundSelect) UnionAll(other *SelectStmt) *CompoundSelect {
+	stmt.Operations = append(stmt.Operations, setOperation{Operator: UnionAllOperator, Stmt: other})
+	return stmt
+}
+
+// Intersect appends another SELECT to the compound statement with
+// INTERSECT.
+func (stmt *CompoundSelect) Intersect(other *SelectStmt) *CompoundSelect {
+	stmt.Operations = append(stmt.Operations, setOperation{Operator: IntersectOperator, Stmt: other})
+	return stmt
+}
+
+// Except appends another SELECT to the compound statement with
+// EXCEPT.
+func (stmt *CompoundSelect) Except(other *SelectStmt) *CompoundSelect {
+	stmt.Operations = append(stmt.Operations, setOperation{Operator: ExceptOperator, Stmt: other})
+	return stmt
+}
+
+// OrderBy sets an ORDER BY clause applying to the compound statement
+// as a whole.
+func (stmt *CompoundSelect) OrderBy(cols ...OrderColumn) *CompoundSelect {
+	stmt.Ordering = append(stmt.Ordering, cols...)
+	return stmt
+}
+
+// Limit limits the number of rows returned by the compound statement
+// as a whole.
+func (stmt *CompoundSelect) Limit(limit int64) *CompoundSelect {
+	stmt.LimitTo = limit
+	return stmt
+}
+
+// Offset skips the provided number of rows from the compound
+// statement's combined results.
+func (stmt *CompoundSelect) Offset(start int64, rows ...int64) *CompoundSelect {
+	stmt.OffsetFrom = start
+	if len(rows) > 0 {
+		stmt.OffsetRows = rows[0]
+	}
+	return stmt
+}
+
+// ToSQL generates the compound statement's SQL and returns a list of
+// bindings, wrapping each branch in parentheses and concatenating
+// bindings left-to-right.
+func (stmt *CompoundSelect) ToSQL(rebind bool) (asSQL string, bindings []interface{}) {
+	dialect := defaultDialect(stmt.Dialect)
+
+	var buf bytes.Buffer
+
+	baseSQL, baseBindings := stmt.Base.ToSQL(false)
+	buf.WriteString("(" + baseSQL + ")")
+	bindings = append(bindings, baseBindings...)
+
+	for _, op := range stmt.Operations {
+		opSQL, opBindings := op.Stmt.ToSQL(false)
+		buf.WriteString(" " + op.Operator.String() + " (" + opSQL + ")")
+		bindings = append(bindings, opBindings...)
+	}
+
+	if len(stmt.Ordering) > 0 {
+		var ordering []string
+		for _, order := range stmt.Ordering {
+			ordering = append(ordering, order.ToSQL())
+			bindings = append(bindings, order.Args...)
+		}
+		buf.WriteString(" ORDER BY " + strings.Join(ordering, ", "))
+	}
+
+	buf.WriteString(dialect.LimitOffset(stmt.LimitTo, stmt.OffsetFrom, stmt.OffsetRows))
+
+	asSQL = buf.String()
+
+	if rebind {
+		asSQL = dialect.Rebind(asSQL)
+	}
+
+	return asSQL, bindings
+}
+
+// GetRow executes the compound statement and loads the first result
+// into the provided variable.
+func (stmt *CompoundSelect) GetRow(into interface{}) error {
+	asSQL, bindings := stmt.ToSQL(true)
+	return sqlx.Get(stmt.queryer, into, asSQL, bindings...)
+}
+
+// GetAll executes the compound statement and loads all the results
+// into the provided slice variable.
+func (stmt *CompoundSelect) GetAll(into interface{}) error {
+	asSQL, bindings := stmt.ToSQL(true)
+	return sqlx.Select(stmt.queryer, into, asSQL, bindings...)
+}
+
+// GetCount executes the compound statement disregarding its limit,
+// offset and ordering, and returns the total number of matching
+// results.
+func (stmt *CompoundSelect) GetCount() (count int64, err error) {
+	countStmt := *stmt
+	countStmt.LimitTo = 0
+	countStmt.OffsetFrom = 0
+	countStmt.OffsetRows = 0
+	countStmt.Ordering = []OrderColumn{}
+
+	innerSQL, bindings := countStmt.ToSQL(false)
+	asSQL := defaultDialect(stmt.Dialect).Rebind("SELECT COUNT(*) FROM (" + innerSQL + ") sqlz_count")
+
+	err = sqlx.Get(stmt.queryer, &count, asSQL, bindings...)
+	return count, err
+}
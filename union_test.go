@@ -0,0 +1,48 @@
+package sqlz
+
+import "testing"
+
+func TestCompoundSelectParensAndBindingOrder(t *testing.T) {
+	active := &SelectStmt{Columns: []string{"id"}, Table: "users"}
+	active.Where(Eq("status", "active"))
+
+	pending := &SelectStmt{Columns: []string{"id"}, Table: "users"}
+	pending.Where(Eq("status", "pending"))
+
+	banned := &SelectStmt{Columns: []string{"id"}, Table: "users"}
+	banned.Where(Eq("status", "banned"))
+
+	compound := active.Union(pending).Except(banned)
+	compound.OrderBy(Asc("id"))
+	compound.Limit(10)
+
+	asSQL, bindings := compound.ToSQL(false)
+	want := "(SELECT id FROM users WHERE status = ?) UNION (SELECT id FROM users WHERE status = ?) " +
+		"EXCEPT (SELECT id FROM users WHERE status = ?) ORDER BY id ASC LIMIT 10"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+
+	wantBindings := []interface{}{"active", "pending", "banned"}
+	for i, b := range wantBindings {
+		if bindings[i] != b {
+			t.Errorf("bindings[%d] = %v, want %v", i, bindings[i], b)
+		}
+	}
+}
+
+func TestCompoundSelectIntersectRebind(t *testing.T) {
+	a := &SelectStmt{Columns: []string{"id"}, Table: "users", Dialect: Postgres}
+	a.Where(Eq("role", "admin"))
+
+	b := &SelectStmt{Columns: []string{"id"}, Table: "users", Dialect: Postgres}
+	b.Where(Eq("active", true))
+
+	compound := a.Intersect(b)
+
+	asSQL, _ := compound.ToSQL(true)
+	want := "(SELECT id FROM users WHERE role = $1) INTERSECT (SELECT id FROM users WHERE active = $2)"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+}
@@ -0,0 +1,49 @@
+package sqlz
+
+import "strings"
+
+// ValuesClause represents an inline VALUES list that can be used as a
+// joinable/from-able row source, e.g. for bulk correlated joins or to
+// feed literal rows into a JOIN's ON conditions.
+type ValuesClause struct {
+	Rows    [][]interface{}
+	Columns []string
+	Dialect Dialect
+}
+
+// Values creates a ValuesClause from the given rows, optionally
+// naming its columns (used to render the "(col1, col2)" alias suffix
+// when joined with Join/LeftJoinRS/etc, or used as a FROM source
+// with SelectStmt.FromValues). Used as a join's result set, it
+// renders as:
+//
+//	(VALUES (?, ?), (?, ?)) alias(col1, col2)
+//
+// Used as a FROM source, it renders as:
+//
+//	FROM (VALUES (?, ?), (?, ?)) alias(col1, col2)
+func Values(rows [][]interface{}, cols ...string) *ValuesClause {
+	return &ValuesClause{Rows: rows, Columns: cols}
+}
+
+// ToSQL generates the "VALUES (...), (...)" SQL for the clause and
+// returns its bindings in row-major order.
+func (v *ValuesClause) ToSQL(rebind bool) (asSQL string, bindings []interface{}) {
+	var rows []string
+	for _, row := range v.Rows {
+		placeholders := make([]string, len(row))
+		for i := range row {
+			placeholders[i] = "?"
+		}
+		rows = append(rows, "("+strings.Join(placeholders, ", ")+")")
+		bindings = append(bindings, row...)
+	}
+
+	asSQL = "VALUES " + strings.Join(rows, ", ")
+
+	if rebind {
+		asSQL = defaultDialect(v.Dialect).Rebind(asSQL)
+	}
+
+	return asSQL, bindings
+}
@@ -0,0 +1,46 @@
+package sqlz
+
+import "testing"
+
+func TestValuesClauseFrom(t *testing.T) {
+	stmt := &SelectStmt{Columns: []string{"*"}}
+	stmt.FromValues(Values([][]interface{}{{1, "a"}, {2, "b"}}, "id", "name"), "v")
+
+	asSQL, bindings := stmt.ToSQL(false)
+	want := "SELECT * FROM (VALUES (?, ?), (?, ?)) v(id, name)"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+	if len(bindings) != 4 || bindings[0] != 1 || bindings[3] != "b" {
+		t.Errorf("bindings = %v, want [1 a 2 b]", bindings)
+	}
+}
+
+func TestValuesClauseJoinDialectPropagation(t *testing.T) {
+	stmt := &SelectStmt{Columns: []string{"*"}, Table: "users", Dialect: MySQL}
+	values := Values([][]interface{}{{1}}, "id")
+	stmt.InnerJoinRS(values, "v", Eq("users.id", Indirect("v.id")))
+
+	asSQL, _ := stmt.ToSQL(true)
+	want := "SELECT * FROM users INNER JOIN (VALUES (?)) v(id) ON users.id = v.id"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+	if values.Dialect != MySQL {
+		t.Errorf("values.Dialect = %v, want MySQL", values.Dialect)
+	}
+}
+
+func TestJoinLateral(t *testing.T) {
+	stmt := &SelectStmt{Columns: []string{"*"}, Table: "users u"}
+	stmt.LeftJoinLateral(
+		&SelectStmt{Columns: []string{"*"}, Table: "orders o"},
+		"recent",
+	)
+
+	asSQL, _ := stmt.ToSQL(false)
+	want := "SELECT * FROM users u LEFT JOIN LATERAL (SELECT * FROM orders o) recent ON true"
+	if asSQL != want {
+		t.Errorf("ToSQL = %q, want %q", asSQL, want)
+	}
+}
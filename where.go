@@ -0,0 +1,99 @@
+package sqlz
+
+import "strings"
+
+// WhereCondition represents a single condition (or group of
+// conditions) usable in a WHERE, HAVING or JOIN ON clause.
+type WhereCondition interface {
+	parse() (asSQL string, bindings []interface{})
+}
+
+// Indirect marks a raw SQL fragment that should be embedded verbatim
+// in a condition instead of being bound as a parameter - typically
+// another column, e.g. Eq("a.id", Indirect("b.id")) renders
+// "a.id = b.id" rather than "a.id = ?".
+type Indirect string
+
+// SimpleCondition is a WhereCondition comparing a column against a
+// value (or, via Indirect, another column) with a single operator.
+type SimpleCondition struct {
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+func (c SimpleCondition) parse() (string, []interface{}) {
+	if ind, ok := c.Value.(Indirect); ok {
+		return c.Column + " " + c.Operator + " " + string(ind), nil
+	}
+	return c.Column + " " + c.Operator + " ?", []interface{}{c.Value}
+}
+
+// Eq creates an equality ("=") condition
+func Eq(col string, val interface{}) WhereCondition {
+	return SimpleCondition{Column: col, Operator: "=", Value: val}
+}
+
+// Neq creates an inequality ("<>") condition
+func Neq(col string, val interface{}) WhereCondition {
+	return SimpleCondition{Column: col, Operator: "<>", Value: val}
+}
+
+// Gt creates a greater-than (">") condition
+func Gt(col string, val interface{}) WhereCondition {
+	return SimpleCondition{Column: col, Operator: ">", Value: val}
+}
+
+// Gte creates a greater-than-or-equal (">=") condition
+func Gte(col string, val interface{}) WhereCondition {
+	return SimpleCondition{Column: col, Operator: ">=", Value: val}
+}
+
+// Lt creates a less-than ("<") condition
+func Lt(col string, val interface{}) WhereCondition {
+	return SimpleCondition{Column: col, Operator: "<", Value: val}
+}
+
+// Lte creates a less-than-or-equal ("<=") condition
+func Lte(col string, val interface{}) WhereCondition {
+	return SimpleCondition{Column: col, Operator: "<=", Value: val}
+}
+
+// Like creates a LIKE condition
+func Like(col string, val interface{}) WhereCondition {
+	return SimpleCondition{Column: col, Operator: "LIKE", Value: val}
+}
+
+// groupCondition is a WhereCondition combining other conditions with
+// AND or OR, wrapped in parentheses.
+type groupCondition struct {
+	Joiner     string
+	Conditions []WhereCondition
+}
+
+func (g groupCondition) parse() (string, []interface{}) {
+	asSQL, bindings := parseConditions(g.Conditions, g.Joiner)
+	return "(" + asSQL + ")", bindings
+}
+
+// And groups conditions together with AND
+func And(conditions ...WhereCondition) WhereCondition {
+	return groupCondition{Joiner: " AND ", Conditions: conditions}
+}
+
+// Or groups conditions together with OR
+func Or(conditions ...WhereCondition) WhereCondition {
+	return groupCondition{Joiner: " OR ", Conditions: conditions}
+}
+
+// parseConditions renders a slice of conditions joined by joiner
+// (conditions passed to Where/Having/Join are always ANDed together).
+func parseConditions(conds []WhereCondition, joiner string) (asSQL string, bindings []interface{}) {
+	var parts []string
+	for _, cond := range conds {
+		condSQL, condBindings := cond.parse()
+		parts = append(parts, condSQL)
+		bindings = append(bindings, condBindings...)
+	}
+	return strings.Join(parts, joiner), bindings
+}